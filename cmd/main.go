@@ -6,8 +6,9 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os/signal"
 	"strings"
-	"time"
+	"syscall"
 
 	"github.com/zerospiel/portforward"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,11 +55,24 @@ func main() {
 	pf.Name = resource
 	pf.ListenPort = listenPort
 
-	err = pf.Start(context.Background())
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err = pf.Start(ctx)
 	if err != nil {
 		log.Fatal("Error starting port forward: ", err)
 	}
 
 	log.Printf("Started tunnel on %d\n", pf.ListenPort)
-	time.Sleep(60 * time.Second)
+
+	select {
+	case <-ctx.Done():
+		pf.Stop()
+		<-pf.Done()
+	case <-pf.Done():
+	}
+
+	if err := pf.Wait(); err != nil && err != context.Canceled {
+		log.Fatal("Port forward stopped with error: ", err)
+	}
 }
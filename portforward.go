@@ -3,16 +3,25 @@ package portforward
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
@@ -21,6 +30,112 @@ import (
 	"k8s.io/client-go/transport/spdy"
 )
 
+// initialBackoff and maxBackoff bound the delay between retries of a failed
+// forward; the delay doubles after each consecutive failure up to maxBackoff.
+const (
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+)
+
+// Action tells the supervised forward loop how to react to an error returned
+// by ForwardPorts, either from the default handling or from OnError.
+type Action int
+
+const (
+	// ActionStop ends the forward and surfaces the error to Start/Wait.
+	ActionStop Action = iota
+	// ActionRetry restarts the forward against the same resource.
+	ActionRetry
+	// ActionRediscover forgets the currently resolved resource name, forcing
+	// getResourceName to run findResourceByLabels again, before restarting the
+	// forward against whatever it resolves to.
+	ActionRediscover
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionStop:
+		return "stop"
+	case ActionRetry:
+		return "retry"
+	case ActionRediscover:
+		return "rediscover"
+	default:
+		return "unknown"
+	}
+}
+
+// State reports what the supervised forward loop is currently doing.
+type State int
+
+const (
+	// StateIdle is the zero value, before Start has been called.
+	StateIdle State = iota
+	// StateForwarding means ForwardPorts is currently running.
+	StateForwarding
+	// StateRetrying means a forward failed and the loop is waiting out its
+	// backoff before trying again.
+	StateRetrying
+	// StateStopped means the loop has exited and will not retry.
+	StateStopped
+)
+
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateForwarding:
+		return "forwarding"
+	case StateRetrying:
+		return "retrying"
+	case StateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// SelectorStrategy picks among ready candidates returned for a Service forward.
+type SelectorStrategy int
+
+const (
+	// SelectRandom picks a uniformly random ready candidate. This is the default.
+	SelectRandom SelectorStrategy = iota
+	// SelectRoundRobin cycles through ready candidates in order, with the cycle
+	// position kept on the PortForward so it advances across reconnects.
+	SelectRoundRobin
+	// SelectSticky deterministically picks a candidate by hashing StickyKey, so the
+	// same key resolves to the same candidate as long as the candidate set is stable.
+	SelectSticky
+)
+
+func (s SelectorStrategy) String() string {
+	switch s {
+	case SelectRandom:
+		return "random"
+	case SelectRoundRobin:
+		return "round-robin"
+	case SelectSticky:
+		return "sticky"
+	default:
+		return "unknown"
+	}
+}
+
+// PortMapping describes a single local<->remote port to forward. Use Name to look up a
+// specific mapping's bound Local port via Ports() when forwarding more than one port to
+// the same resource (e.g. an HTTP port alongside a gRPC one).
+type PortMapping struct {
+	// Local is the local port to listen on; if zero, a free port is allocated and the
+	// resolved value is written back here once the tunnel is ready.
+	Local int
+	// Remote is the port on the resource to forward traffic to; if zero, it is resolved
+	// from DestinationPortAnnotation (or, for a Service, a named TargetPort).
+	Remote int
+	// Name identifies this mapping for lookup via Ports().
+	Name string
+}
+
 type ResourceForwardOption func(*PortForward) *PortForward
 
 func WithPodForward() ResourceForwardOption {
@@ -37,11 +152,77 @@ func WithServiceForward() ResourceForwardOption {
 	}
 }
 
+func WithDeploymentForward() ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.resType = deploymentType
+		return pf
+	}
+}
+
+func WithStatefulSetForward() ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.resType = statefulSetType
+		return pf
+	}
+}
+
+func WithDaemonSetForward() ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.resType = daemonSetType
+		return pf
+	}
+}
+
+// DefaultPortAnnotation is the annotation key consulted for the remote port when
+// DestinationPort is zero and no DestinationPortAnnotation has been set.
+const DefaultPortAnnotation = "portforward.zerospiel/port"
+
+// WithAnnotationPort sets DestinationPortAnnotation, the pod/service annotation (e.g.
+// "prometheus.io/port") that the resolver reads for the remote port when
+// DestinationPort is zero.
+func WithAnnotationPort(annotation string) ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.DestinationPortAnnotation = annotation
+		return pf
+	}
+}
+
+// WithOnError registers a hook invoked whenever ForwardPorts returns an error,
+// for example because the backing pod was evicted or the SPDY stream dropped.
+// The returned Action decides whether the tunnel stops, retries against the
+// same resource, or rediscovers a fresh one before retrying.
+func WithOnError(fn func(err error) Action) ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.OnError = fn
+		return pf
+	}
+}
+
+// WithSelector sets the strategy used to pick among ready pods behind a Service forward.
+func WithSelector(s SelectorStrategy) ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.Selector = s
+		return pf
+	}
+}
+
+// WithStickyKey sets StickyKey, the value hashed to pick a candidate when Selector is
+// SelectSticky.
+func WithStickyKey(key string) ResourceForwardOption {
+	return func(pf *PortForward) *PortForward {
+		pf.StickyKey = key
+		return pf
+	}
+}
+
 type resType int
 
 const (
 	podType resType = iota
 	serviceType
+	deploymentType
+	statefulSetType
+	daemonSetType
 )
 
 func (t resType) String() string {
@@ -50,6 +231,12 @@ func (t resType) String() string {
 		return "pod"
 	case serviceType:
 		return "service"
+	case deploymentType:
+		return "deployment"
+	case statefulSetType:
+		return "statefulset"
+	case daemonSetType:
+		return "daemonset"
 	default:
 		return "unknown"
 	}
@@ -65,6 +252,16 @@ type PortForward struct {
 
 	stopChan  chan struct{}
 	readyChan chan struct{}
+	closeStop func()
+
+	// stopRequested is closed exactly once, by Stop, to signal every attempt
+	// (current and future) started by Start to shut down.
+	stopRequested chan struct{}
+	stopClosed    bool
+	// done is closed once the supervisor loop has exited for good; Done and Wait
+	// read it.
+	done    chan struct{}
+	waitErr error
 
 	// The resource name to use, required if Labels is empty.
 	Name string
@@ -73,12 +270,52 @@ type PortForward struct {
 
 	// The labels to use to find the resource.
 	Labels metav1.LabelSelector
-	// The port on the resource to forward traffic to.
+
+	// Mappings lists the local<->remote ports to forward. If empty, a single mapping is
+	// synthesized from DestinationPort and ListenPort. Once the tunnel is ready, each
+	// mapping's Local (and, if resolved via an annotation, Remote) is populated; read it
+	// back via Ports().
+	Mappings []PortMapping
+
+	// Deprecated: set Mappings instead. The port on the resource to forward traffic to,
+	// used to synthesize a single PortMapping when Mappings is empty. If zero, it is
+	// resolved from DestinationPortAnnotation (or, for a Service, a named TargetPort).
 	DestinationPort int
-	// The port that the port forward should listen to, random if not set.
+	// Deprecated: set Mappings instead. The port that the port forward should listen to,
+	// used to synthesize a single PortMapping when Mappings is empty. Random if not set.
 	ListenPort int
 
+	// DestinationPortAnnotation names the pod/service annotation consulted for the
+	// remote port when DestinationPort is zero. Defaults to DefaultPortAnnotation.
+	DestinationPortAnnotation string
+
+	// Selector picks among ready pods behind a Service forward. Defaults to
+	// SelectRandom.
+	Selector SelectorStrategy
+	// StickyKey is hashed to deterministically pick a candidate when Selector is
+	// SelectSticky, e.g. a caller or session identifier to pin to the same pod
+	// across reconnects.
+	StickyKey string
+
+	resolvedServiceName string
+	rrCounter           uint64
+
+	// OnError, if set, is called whenever ForwardPorts returns an error or the
+	// tunnel otherwise fails, so callers can decide whether to stop, retry, or
+	// rediscover the backing resource (e.g. after a pod reschedule).
+	OnError func(err error) Action
+
+	// Attempts is the number of times ForwardPorts has been (re)started.
+	Attempts int
+	// LastError is the most recent error that ended a forward attempt, if any,
+	// whether from resolving the resource, dialing, or ForwardPorts itself.
+	LastError error
+	// State reports what the supervised forward loop is currently doing.
+	State State
+
 	resType resType
+
+	mu sync.Mutex
 }
 
 // Initialize a port forwarder, loads the Kubernetes configuration file and creates the client.
@@ -114,46 +351,300 @@ func NewPortForwarder(namespace string, labels metav1.LabelSelector, port int, o
 }
 
 // Start a port forward to a resource - blocks until the tunnel is ready for use.
+// Once ready, a supervisor goroutine keeps the tunnel running: if ForwardPorts
+// fails, OnError (when set) decides whether to stop, retry, or rediscover the
+// backing resource, with exponential backoff between attempts. Call Done or Wait
+// to block on the tunnel terminating; canceling ctx or calling Stop ends it.
 func (p *PortForward) Start(ctx context.Context) error {
-	p.stopChan = make(chan struct{}, 1)
-	p.readyChan = make(chan struct{}, 1)
-	errChan := make(chan error, 1)
+	p.mu.Lock()
+	p.stopRequested = make(chan struct{})
+	p.stopClosed = false
+	p.done = make(chan struct{})
+	p.waitErr = nil
+	p.mu.Unlock()
+
+	ready := make(chan error, 1)
+	go p.superviseForward(ctx, ready)
+	return <-ready
+}
+
+// superviseForward runs (and, on failure, restarts) the forward until the context is
+// canceled, Stop is called, or handleForwardError decides to stop. ready is only ever
+// written to once, for the very first attempt, mirroring the readiness semantics the
+// previous single-shot Start had.
+func (p *PortForward) superviseForward(ctx context.Context, ready chan<- error) {
+	backoff := initialBackoff
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.finish(ctx.Err(), ready)
+			return
+		case <-p.stopRequested:
+			p.finish(nil, ready)
+			return
+		default:
+		}
+
+		becameReady, err := p.runOnce(ctx, ready)
+		ready = nil
+
+		if becameReady {
+			// The tunnel became ready before this attempt ended, so the next
+			// backoff should reflect consecutive failures from here, not whatever
+			// backoff earlier, unrelated failures had climbed to.
+			backoff = initialBackoff
+		}
+
+		// runOnce returns nil both for a clean stop and when ctx cancellation closed
+		// stopChan out from under it, so ctx must be checked first to report the
+		// latter as a cancellation rather than a clean stop.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			p.finish(ctxErr, ready)
+			return
+		}
+
+		if err == nil {
+			p.finish(nil, ready)
+			return
+		}
+
+		if !p.handleForwardError(ctx, err) {
+			p.finish(err, ready)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			p.finish(ctx.Err(), ready)
+			return
+		case <-p.stopRequested:
+			p.finish(nil, ready)
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
 
-	listenPort, err := p.getListenPort()
+// finish records err as the terminal error, reports it to Start's caller if ready is
+// still non-nil (the loop ended before its first attempt ran), and closes Done.
+func (p *PortForward) finish(err error, ready chan<- error) {
+	p.setState(StateStopped)
+
+	p.mu.Lock()
+	p.waitErr = err
+	done := p.done
+	p.mu.Unlock()
+
+	if ready != nil {
+		ready <- err
+	}
+	close(done)
+}
+
+// forwarder is the subset of *portforward.PortForwarder that runOnce drives;
+// newPortForwarder is a var so tests can substitute a fake and exercise
+// runOnce/superviseForward without a real apiserver to dial.
+type forwarder interface {
+	ForwardPorts() error
+}
+
+var newPortForwarder = func(dialer httpstream.Dialer, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut io.Writer) (forwarder, error) {
+	return portforward.New(dialer, ports, stopChan, readyChan, out, errOut)
+}
+
+// newDialer is a var so tests can substitute a fake and exercise
+// runOnce/superviseForward without a real apiserver to dial against.
+var newDialer = func(p *PortForward, resourceName string) (httpstream.Dialer, error) {
+	return p.dialer(resourceName)
+}
+
+// runOnce resolves the resource, dials, and blocks until ForwardPorts returns.
+// If ready is non-nil, it is written to exactly once as soon as the tunnel is
+// either ready or has failed to become ready. The returned bool reports
+// whether the tunnel became ready at all during this attempt, so the caller
+// can tell a sustained connection that later failed apart from one that never
+// came up, e.g. to decide whether to reset its retry backoff.
+func (p *PortForward) runOnce(ctx context.Context, ready chan<- error) (bool, error) {
+	resourceName, err := p.getResourceName(ctx)
 	if err != nil {
-		return fmt.Errorf("could not find a port to bind to: %w", err)
+		err = fmt.Errorf("could not get %s name: %w", p.resType, err)
+		if ready != nil {
+			ready <- err
+		}
+		return false, err
 	}
 
-	dialer, err := p.dialer(ctx)
+	dialer, err := newDialer(p, resourceName)
 	if err != nil {
-		return fmt.Errorf("could not create a dialer: %w", err)
+		err = fmt.Errorf("could not create a dialer: %w", err)
+		if ready != nil {
+			ready <- err
+		}
+		return false, err
 	}
 
-	ports := []string{
-		fmt.Sprintf("%d:%d", listenPort, p.DestinationPort),
+	mappings, err := p.resolveMappings(ctx, resourceName)
+	if err != nil {
+		if ready != nil {
+			ready <- err
+		}
+		return false, err
 	}
 
+	ports := make([]string, len(mappings))
+	for i, m := range mappings {
+		ports[i] = fmt.Sprintf("%d:%d", m.Local, m.Remote)
+	}
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{}, 1)
+
+	var stopOnce sync.Once
+	closeStop := func() { stopOnce.Do(func() { close(stopChan) }) }
+
 	discard := ioutil.Discard
-	pf, err := portforward.New(dialer, ports, p.stopChan, p.readyChan, discard, discard)
+	pf, err := newPortForwarder(dialer, ports, stopChan, readyChan, discard, discard)
 	if err != nil {
-		return fmt.Errorf("could not port forward into %s: %w", p.resType, err)
+		err = fmt.Errorf("could not port forward into %s: %w", p.resType, err)
+		if ready != nil {
+			ready <- err
+		}
+		return false, err
 	}
 
+	p.mu.Lock()
+	p.stopChan = stopChan
+	p.readyChan = readyChan
+	p.closeStop = closeStop
+	p.Mappings = mappings
+	p.Attempts++
+	p.mu.Unlock()
+	p.setState(StateForwarding)
+
+	// This goroutine propagates ctx cancellation and Stop calls into this attempt's
+	// blocking ForwardPorts call below; it exits once the attempt ends, and each
+	// later attempt starts its own.
+	watchDone := make(chan struct{})
+	defer close(watchDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeStop()
+		case <-p.stopRequested:
+			closeStop()
+		case <-watchDone:
+		}
+	}()
+
+	errChan := make(chan error, 1)
 	go func() {
 		errChan <- pf.ForwardPorts()
 	}()
 
 	select {
 	case err = <-errChan:
-		return fmt.Errorf("could not create port forward: %w", err)
-	case <-p.readyChan:
-		return nil
+		if ready != nil {
+			ready <- fmt.Errorf("could not create port forward: %w", err)
+		}
+		return false, err
+	case <-readyChan:
+		p.mu.Lock()
+		p.Mappings = mappings
+		p.mu.Unlock()
+		if ready != nil {
+			ready <- nil
+		}
 	}
+
+	return true, <-errChan
 }
 
-// Stop a port forward.
+// handleForwardError records err, consults OnError (defaulting to ActionStop
+// when unset), and applies the resulting Action. It reports whether the
+// supervisor loop should retry.
+func (p *PortForward) handleForwardError(ctx context.Context, err error) bool {
+	p.mu.Lock()
+	p.LastError = err
+	p.mu.Unlock()
+
+	action := ActionStop
+	if p.OnError != nil {
+		action = p.OnError(err)
+	}
+
+	switch action {
+	case ActionRediscover:
+		p.mu.Lock()
+		p.Name = ""
+		p.mu.Unlock()
+		p.setState(StateRetrying)
+		return true
+	case ActionRetry:
+		p.setState(StateRetrying)
+		return true
+	default:
+		p.setState(StateStopped)
+		return false
+	}
+}
+
+func (p *PortForward) setState(s State) {
+	p.mu.Lock()
+	p.State = s
+	p.mu.Unlock()
+}
+
+// Stop the port forward. It is safe to call multiple times, including
+// concurrently; only the first call has an effect. Stop does not block until
+// the tunnel has actually shut down - use Wait or Done for that.
 func (p *PortForward) Stop() {
-	p.stopChan <- struct{}{}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopRequested == nil || p.stopClosed {
+		return
+	}
+	p.stopClosed = true
+	close(p.stopRequested)
+	if p.closeStop != nil {
+		p.closeStop()
+	}
+}
+
+// Done returns a channel that is closed once the supervised forward has
+// stopped for good, whether because of ctx cancellation, a Stop call, or
+// handleForwardError giving up.
+func (p *PortForward) Done() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done
+}
+
+// Wait blocks until the supervised forward has stopped, then returns the
+// reason: the ctx error if canceled, nil if stopped via Stop or a clean
+// ForwardPorts exit, or the terminal error once handleForwardError gives up
+// (OnError returned ActionStop, or OnError is unset).
+func (p *PortForward) Wait() error {
+	<-p.Done()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.waitErr
+}
+
+// Ports returns each configured port mapping with its resolved Local and Remote ports.
+// Local is only populated once the tunnel is ready, i.e. after Start has returned.
+func (p *PortForward) Ports() []PortMapping {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	mappings := make([]PortMapping, len(p.Mappings))
+	copy(mappings, p.Mappings)
+	return mappings
 }
 
 // Returns the port that the port forward should listen on.
@@ -186,13 +677,115 @@ func (p *PortForward) getFreePort() (int, error) {
 	return port, nil
 }
 
-// Create an httpstream.Dialer for use with portforward.New
-func (p *PortForward) dialer(ctx context.Context) (httpstream.Dialer, error) {
-	resourceName, err := p.getResourceName(ctx)
+// resolveMappings returns the effective port mappings for this forward: Mappings if set,
+// otherwise a single mapping synthesized from the legacy ListenPort/DestinationPort fields.
+// Each mapping's Remote is resolved via resolveDestinationPort if zero, and its Local is
+// assigned a free local port if zero.
+func (p *PortForward) resolveMappings(ctx context.Context, resourceName string) ([]PortMapping, error) {
+	mappings := p.Mappings
+	if len(mappings) == 0 {
+		local, err := p.getListenPort()
+		if err != nil {
+			return nil, fmt.Errorf("could not find a port to bind to: %w", err)
+		}
+		mappings = []PortMapping{{Local: local, Remote: p.DestinationPort}}
+	}
+
+	resolved := make([]PortMapping, len(mappings))
+	for i, m := range mappings {
+		remote, err := p.resolveDestinationPort(ctx, resourceName, m.Remote)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve a destination port for mapping %q: %w", m.Name, err)
+		}
+		m.Remote = remote
+
+		if m.Local == 0 {
+			local, err := p.getFreePort()
+			if err != nil {
+				return nil, fmt.Errorf("could not find a port to bind to for mapping %q: %w", m.Name, err)
+			}
+			m.Local = local
+		}
+
+		resolved[i] = m
+	}
+
+	return resolved, nil
+}
+
+// resolveDestinationPort returns the remote port to forward to. If remote is non-zero it
+// is used as-is. Otherwise it is read from DestinationPortAnnotation (or
+// DefaultPortAnnotation) on resourceName's pod and, for a Service forward, the backing
+// Service; failing that, a Service forward falls back to that Service's first TargetPort,
+// resolving a named TargetPort against the pod's containerPort by name.
+func (p *PortForward) resolveDestinationPort(ctx context.Context, resourceName string, remote int) (int, error) {
+	if remote != 0 {
+		return remote, nil
+	}
+
+	annotation := p.DestinationPortAnnotation
+	if annotation == "" {
+		annotation = DefaultPortAnnotation
+	}
+
+	pod, err := p.Clientset.CoreV1().Pods(p.Namespace).Get(ctx, resourceName, metav1.GetOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("could not get %s name: %w", p.resType, err)
+		return 0, fmt.Errorf("getting pod %s: %w", resourceName, err)
 	}
 
+	if raw, ok := pod.Annotations[annotation]; ok {
+		return parseAnnotationPort(annotation, "pod", resourceName, raw)
+	}
+
+	if p.resType != serviceType || p.resolvedServiceName == "" {
+		return 0, fmt.Errorf("pod %s has no %q annotation", resourceName, annotation)
+	}
+
+	svc, err := p.Clientset.CoreV1().Services(p.Namespace).Get(ctx, p.resolvedServiceName, metav1.GetOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("getting service %s: %w", p.resolvedServiceName, err)
+	}
+
+	if raw, ok := svc.Annotations[annotation]; ok {
+		return parseAnnotationPort(annotation, "service", p.resolvedServiceName, raw)
+	}
+
+	if len(svc.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("service %s has no %q annotation and exposes no ports", p.resolvedServiceName, annotation)
+	}
+
+	return resolveContainerPort(pod, svc.Spec.Ports[0].TargetPort)
+}
+
+func parseAnnotationPort(annotation, kind, name, raw string) (int, error) {
+	port, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("annotation %q on %s %s is not a valid port: %w", annotation, kind, name, err)
+	}
+	return port, nil
+}
+
+// resolveContainerPort resolves a Service's TargetPort against a pod's container ports,
+// matching Kubernetes' own IntOrString semantics: a numeric TargetPort is used directly,
+// a named one is looked up by name across the pod's containers.
+func resolveContainerPort(pod *v1.Pod, targetPort intstr.IntOrString) (int, error) {
+	if targetPort.Type == intstr.Int {
+		return targetPort.IntValue(), nil
+	}
+
+	for _, c := range pod.Spec.Containers {
+		for _, cp := range c.Ports {
+			if cp.Name == targetPort.StrVal {
+				return int(cp.ContainerPort), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("could not find container port named %q on pod %s", targetPort.StrVal, pod.Name)
+}
+
+// Create an httpstream.Dialer for use with portforward.New
+func (p *PortForward) dialer(resourceName string) (httpstream.Dialer, error) {
 	url := p.Clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Namespace(p.Namespace).
@@ -209,8 +802,16 @@ func (p *PortForward) dialer(ctx context.Context) (httpstream.Dialer, error) {
 }
 
 // Gets the resource name to port forward to, if Name is set, Name is returned. Otherwise,
-// it will call findResourceByLabels().
+// it will call findResourceByLabels(). For a StatefulSet, Name may instead be given as
+// "<statefulset>/<ordinal>" to deterministically pin a specific replica, which is resolved
+// here to that replica's pod name.
 func (p *PortForward) getResourceName(ctx context.Context) (string, error) {
+	if p.resType == statefulSetType && p.Name != "" {
+		if podName, ok := statefulSetOrdinalPodName(p.Name); ok {
+			p.Name = podName
+		}
+	}
+
 	var err error
 	if p.Name == "" {
 		p.Name, err = p.findResourceByLabels(ctx)
@@ -218,6 +819,20 @@ func (p *PortForward) getResourceName(ctx context.Context) (string, error) {
 	return p.Name, err
 }
 
+// statefulSetOrdinalPodName parses a Name of the form "<statefulset>/<ordinal>" into the
+// deterministic pod name Kubernetes assigns to that StatefulSet replica. It reports false
+// if name isn't in that form.
+func statefulSetOrdinalPodName(name string) (string, bool) {
+	sts, ordinal, found := strings.Cut(name, "/")
+	if !found {
+		return "", false
+	}
+	if _, err := strconv.Atoi(ordinal); err != nil {
+		return "", false
+	}
+	return sts + "-" + ordinal, true
+}
+
 // Find the name of a resource by label, returns an error if the label returns
 // more or less than one resource.
 // It searches for the labels specified by labels.
@@ -230,7 +845,9 @@ func (p *PortForward) findResourceByLabels(ctx context.Context) (string, error)
 	case podType:
 		return p.getPodName(ctx)
 	case serviceType:
-		return p.getFromEndpoints(ctx)
+		return p.getFromEndpointSlices(ctx)
+	case deploymentType, statefulSetType, daemonSetType:
+		return p.getWorkloadPodName(ctx)
 	default:
 		return "", fmt.Errorf("unknown resource type")
 	}
@@ -257,10 +874,164 @@ func (p *PortForward) getPodName(ctx context.Context) (string, error) {
 	return pods.Items[0].ObjectMeta.Name, nil
 }
 
+// getWorkloadPodName resolves p.resType (Deployment, StatefulSet, or DaemonSet) to a
+// single running pod: it finds the workload matching p.Labels, reads the pod selector
+// off its spec, and picks a running pod matched by that selector at random.
+func (p *PortForward) getWorkloadPodName(ctx context.Context) (string, error) {
+	selector, err := p.findWorkloadSelector(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	formatPodSel := metav1.FormatLabelSelector(selector)
+	pods, err := p.Clientset.CoreV1().Pods(p.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: formatPodSel,
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(v1.PodRunning)).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing pods in kubernetes: %w", err)
+	}
+
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no running %s replicas for selector: labels \"%s\"", p.resType, formatPodSel)
+	}
+
+	return pods.Items[rand.Intn(len(pods.Items))].ObjectMeta.Name, nil
+}
+
+// findWorkloadSelector locates the single Deployment, StatefulSet, or DaemonSet matching
+// p.Labels in p.Namespace and returns its pod selector.
+func (p *PortForward) findWorkloadSelector(ctx context.Context) (*metav1.LabelSelector, error) {
+	formatLabelSel := metav1.FormatLabelSelector(&p.Labels)
+	listOpts := metav1.ListOptions{LabelSelector: formatLabelSel}
+
+	var n int
+	var selector *metav1.LabelSelector
+
+	switch p.resType {
+	case deploymentType:
+		deployments, err := p.Clientset.AppsV1().Deployments(p.Namespace).List(ctx, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("listing deployments in kubernetes: %w", err)
+		}
+		n = len(deployments.Items)
+		if n == 1 {
+			selector = deployments.Items[0].Spec.Selector
+		}
+	case statefulSetType:
+		statefulSets, err := p.Clientset.AppsV1().StatefulSets(p.Namespace).List(ctx, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("listing statefulsets in kubernetes: %w", err)
+		}
+		n = len(statefulSets.Items)
+		if n == 1 {
+			selector = statefulSets.Items[0].Spec.Selector
+		}
+	case daemonSetType:
+		daemonSets, err := p.Clientset.AppsV1().DaemonSets(p.Namespace).List(ctx, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("listing daemonsets in kubernetes: %w", err)
+		}
+		n = len(daemonSets.Items)
+		if n == 1 {
+			selector = daemonSets.Items[0].Spec.Selector
+		}
+	default:
+		return nil, fmt.Errorf("unknown resource type")
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("could not find %s for selector: labels \"%s\"", p.resType, formatLabelSel)
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("ambiguous %s: found more than one %s for selector: labels \"%s\"", p.resType, p.resType, formatLabelSel)
+	}
+
+	return selector, nil
+}
+
 func init() {
 	rand.Seed(time.Now().UnixNano()) //nolint:gosec
 }
 
+// getFromEndpointSlices resolves a Service forward to a single pod using
+// discovery.k8s.io/v1 EndpointSlices, falling back to the legacy Endpoints API on
+// clusters that don't serve EndpointSlices. Candidates are filtered to addresses whose
+// Ready condition is true (and, when reported, Serving), then one is picked per Selector.
+func (p *PortForward) getFromEndpointSlices(ctx context.Context) (string, error) {
+	formatLabelSel := metav1.FormatLabelSelector(&p.Labels)
+
+	slices, err := p.Clientset.DiscoveryV1().EndpointSlices(p.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: formatLabelSel,
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return p.getFromEndpoints(ctx)
+		}
+		return "", fmt.Errorf("listing endpointslices in kubernetes: %w", err)
+	}
+
+	if len(slices.Items) == 0 {
+		return p.getFromEndpoints(ctx)
+	}
+
+	var candidates []discoveryv1.Endpoint
+	for _, slice := range slices.Items {
+		for _, ep := range slice.Endpoints {
+			if ep.TargetRef == nil || !endpointReady(ep) {
+				continue
+			}
+			candidates = append(candidates, ep)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("could not find any ready pods for selector: labels \"%s\"", formatLabelSel)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].TargetRef.Name < candidates[j].TargetRef.Name
+	})
+
+	// EndpointSlices are conventionally labeled with the Service they back, so remember
+	// it for later service-level lookups (annotations, named TargetPort).
+	p.resolvedServiceName = slices.Items[0].Labels[discoveryv1.LabelServiceName]
+
+	return p.selectEndpoint(candidates).TargetRef.Name, nil
+}
+
+// endpointReady reports whether an EndpointSlice endpoint should be considered a
+// forwarding candidate: Ready must not be false, and Serving (when reported) must not
+// be false either.
+func endpointReady(ep discoveryv1.Endpoint) bool {
+	if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+		return false
+	}
+	if ep.Conditions.Serving != nil && !*ep.Conditions.Serving {
+		return false
+	}
+	return true
+}
+
+// selectEndpoint picks one of candidates according to p.Selector, defaulting to
+// SelectRandom.
+func (p *PortForward) selectEndpoint(candidates []discoveryv1.Endpoint) discoveryv1.Endpoint {
+	switch p.Selector {
+	case SelectRoundRobin:
+		p.mu.Lock()
+		i := p.rrCounter % uint64(len(candidates))
+		p.rrCounter++
+		p.mu.Unlock()
+		return candidates[i]
+	case SelectSticky:
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(p.StickyKey))
+		return candidates[h.Sum32()%uint32(len(candidates))]
+	default:
+		return candidates[rand.Intn(len(candidates))]
+	}
+}
+
 func (p *PortForward) getFromEndpoints(ctx context.Context) (string, error) {
 	formatLabelSel := metav1.FormatLabelSelector(&p.Labels)
 	eps, err := p.Clientset.CoreV1().Endpoints(p.Namespace).List(ctx, metav1.ListOptions{
@@ -279,6 +1050,9 @@ func (p *PortForward) getFromEndpoints(ctx context.Context) (string, error) {
 	for _, s := range randEp.Subsets {
 		for _, a := range s.Addresses {
 			if a.TargetRef != nil {
+				// Endpoints share their name with the Service they back, so remember
+				// it for later service-level lookups (annotations, named TargetPort).
+				p.resolvedServiceName = randEp.Name
 				return a.TargetRef.Name, nil
 			}
 		}
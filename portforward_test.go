@@ -2,13 +2,25 @@ package portforward
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 )
 
 func newPod(name string, labels map[string]string) *corev1.Pod {
@@ -24,6 +36,58 @@ func newPod(name string, labels map[string]string) *corev1.Pod {
 	}
 }
 
+func newDeployment(name string, labels map[string]string, selector map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+}
+
+func newStatefulSet(name string, labels map[string]string, selector map[string]string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+}
+
+func newDaemonSet(name string, labels map[string]string, selector map[string]string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func newEndpointSlice(name, serviceName string, labels map[string]string, endpoints []discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	lbls := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		lbls[k] = v
+	}
+	lbls[discoveryv1.LabelServiceName] = serviceName
+
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: name, Labels: lbls},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+	}
+}
+
 func Test_findResourceByLabels(t *testing.T) {
 	pf := PortForward{
 		resType: podType,
@@ -143,6 +207,232 @@ func Test_findResourceByLabels_ExpressionNotFound(t *testing.T) {
 	assert.Equal(t, "could not find running pod for selector: labels \"name in (flux,fluxd)\"", err.Error())
 }
 
+func Test_findResourceByLabels_Deployment(t *testing.T) {
+	pf := PortForward{
+		resType: deploymentType,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newDeployment("web", map[string]string{"app": "web"}, map[string]string{"app": "web"}),
+			newDeployment("other", map[string]string{"app": "other"}, map[string]string{"app": "other"}),
+			newPod("web-1", map[string]string{"app": "web"}),
+			newPod("other-1", map[string]string{"app": "other"})),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "web",
+			},
+		},
+	}
+
+	pod, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "web-1", pod)
+}
+
+func Test_findResourceByLabels_StatefulSet_ZeroReplicasReady(t *testing.T) {
+	pf := PortForward{
+		resType: statefulSetType,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newStatefulSet("db", map[string]string{"app": "db"}, map[string]string{"app": "db"})),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "db",
+			},
+		},
+	}
+
+	_, err := pf.findResourceByLabels(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "no running statefulset replicas for selector: labels \"app=db\"", err.Error())
+}
+
+func Test_findResourceByLabels_DaemonSet_Ambiguous(t *testing.T) {
+	pf := PortForward{
+		resType: daemonSetType,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newDaemonSet("logs1", map[string]string{"app": "logs"}, map[string]string{"app": "logs"}),
+			newDaemonSet("logs2", map[string]string{"app": "logs"}, map[string]string{"app": "logs"})),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "logs",
+			},
+		},
+	}
+
+	_, err := pf.findResourceByLabels(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "ambiguous daemonset: found more than one daemonset for selector: labels \"app=logs\"", err.Error())
+}
+
+func Test_getFromEndpointSlices_FiltersNotReady(t *testing.T) {
+	eps := []discoveryv1.Endpoint{
+		{
+			TargetRef:  &corev1.ObjectReference{Name: "pod-ready"},
+			Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)},
+		},
+		{
+			TargetRef:  &corev1.ObjectReference{Name: "pod-notready"},
+			Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(false)},
+		},
+	}
+
+	pf := PortForward{
+		resType: serviceType,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newEndpointSlice("web-abcde", "web", map[string]string{"app": "web"}, eps)),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "web",
+			},
+		},
+	}
+
+	name, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "pod-ready", name)
+	assert.Equal(t, "web", pf.resolvedServiceName)
+}
+
+func Test_getFromEndpointSlices_RoundRobin(t *testing.T) {
+	eps := []discoveryv1.Endpoint{
+		{
+			TargetRef:  &corev1.ObjectReference{Name: "pod-a"},
+			Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)},
+		},
+		{
+			TargetRef:  &corev1.ObjectReference{Name: "pod-b"},
+			Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)},
+		},
+	}
+
+	pf := PortForward{
+		resType:  serviceType,
+		Selector: SelectRoundRobin,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newEndpointSlice("web-abcde", "web", map[string]string{"app": "web"}, eps)),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "web",
+			},
+		},
+	}
+
+	first, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	second, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.ElementsMatch(t, []string{"pod-a", "pod-b"}, []string{first, second})
+}
+
+func Test_getFromEndpointSlices_Sticky(t *testing.T) {
+	eps := []discoveryv1.Endpoint{
+		{
+			TargetRef:  &corev1.ObjectReference{Name: "pod-a"},
+			Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)},
+		},
+		{
+			TargetRef:  &corev1.ObjectReference{Name: "pod-b"},
+			Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)},
+		},
+	}
+
+	pf := PortForward{
+		resType:   serviceType,
+		Selector:  SelectSticky,
+		StickyKey: "session-42",
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newEndpointSlice("web-abcde", "web", map[string]string{"app": "web"}, eps)),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "web",
+			},
+		},
+	}
+
+	first, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	second, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func Test_getFromEndpointSlices_FallsBackToEndpoints(t *testing.T) {
+	pf := PortForward{
+		resType: serviceType,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "web",
+					Labels: map[string]string{"app": "web"},
+				},
+				Subsets: []corev1.EndpointSubset{
+					{
+						Addresses: []corev1.EndpointAddress{
+							{TargetRef: &corev1.ObjectReference{Name: "pod-legacy"}},
+						},
+					},
+				},
+			}),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "web",
+			},
+		},
+	}
+
+	name, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "pod-legacy", name)
+}
+
+func Test_getFromEndpointSlices_FallsBackToEndpoints_WhenDiscoveryNotServed(t *testing.T) {
+	clientset := fakekubernetes.NewSimpleClientset(
+		&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "web",
+				Labels: map[string]string{"app": "web"},
+			},
+			Subsets: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{
+						{TargetRef: &corev1.ObjectReference{Name: "pod-legacy"}},
+					},
+				},
+			},
+		})
+
+	// Simulate a cluster that doesn't serve discovery.k8s.io/v1 at all, rather
+	// than one that serves it but has no matching EndpointSlices.
+	clientset.PrependReactor("list", "endpointslices", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(discoveryv1.Resource("endpointslices"), "")
+	})
+
+	pf := PortForward{
+		resType:   serviceType,
+		Clientset: clientset,
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "web",
+			},
+		},
+	}
+
+	name, err := pf.findResourceByLabels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "pod-legacy", name)
+}
+
+func Test_getResourceName_StatefulSetOrdinal(t *testing.T) {
+	pf := PortForward{
+		resType: statefulSetType,
+		Name:    "db/2",
+	}
+
+	name, err := pf.getResourceName(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "db-2", name)
+}
+
 func Test_getResourceName_NameSet(t *testing.T) {
 	pf := PortForward{
 		Name: "hello",
@@ -198,3 +488,413 @@ func TestGetListenPortRandom(t *testing.T) {
 	assert.NotZero(t, port)
 	assert.Equal(t, pf.ListenPort, port)
 }
+
+func TestResolveMappings_Multiple(t *testing.T) {
+	pf := PortForward{
+		resType:   podType,
+		Clientset: fakekubernetes.NewSimpleClientset(newPod("mypod", nil)),
+		Mappings: []PortMapping{
+			{Name: "http", Local: 8080, Remote: 80},
+			{Name: "grpc", Remote: 9000},
+		},
+	}
+
+	mappings, err := pf.resolveMappings(context.Background(), "mypod")
+	require.NoError(t, err)
+	require.Len(t, mappings, 2)
+
+	assert.Equal(t, "http", mappings[0].Name)
+	assert.Equal(t, 8080, mappings[0].Local)
+	assert.Equal(t, 80, mappings[0].Remote)
+
+	assert.Equal(t, "grpc", mappings[1].Name)
+	assert.NotZero(t, mappings[1].Local)
+	assert.Equal(t, 9000, mappings[1].Remote)
+}
+
+func TestResolveMappings_LegacyFieldsShim(t *testing.T) {
+	pf := PortForward{
+		DestinationPort: 80,
+	}
+
+	mappings, err := pf.resolveMappings(context.Background(), "mypod")
+	require.NoError(t, err)
+	require.Len(t, mappings, 1)
+	assert.NotZero(t, mappings[0].Local)
+	assert.Equal(t, 80, mappings[0].Remote)
+	assert.Equal(t, mappings[0].Local, pf.ListenPort)
+}
+
+func TestPorts_ReturnsMappings(t *testing.T) {
+	pf := PortForward{}
+	assert.Empty(t, pf.Ports())
+
+	pf.Mappings = []PortMapping{{Name: "http", Local: 8080, Remote: 80}}
+	ports := pf.Ports()
+	require.Len(t, ports, 1)
+	assert.Equal(t, 8080, ports[0].Local)
+}
+
+func TestResolveDestinationPort_NumericAnnotationOnPod(t *testing.T) {
+	pod := newPod("mypod", nil)
+	pod.Annotations = map[string]string{"prometheus.io/port": "9090"}
+
+	pf := PortForward{
+		resType:                   podType,
+		DestinationPortAnnotation: "prometheus.io/port",
+		Clientset:                 fakekubernetes.NewSimpleClientset(pod),
+	}
+
+	port, err := pf.resolveDestinationPort(context.Background(), "mypod", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 9090, port)
+}
+
+func TestResolveDestinationPort_MissingAnnotation(t *testing.T) {
+	pod := newPod("mypod", nil)
+
+	pf := PortForward{
+		resType:                   podType,
+		DestinationPortAnnotation: "prometheus.io/port",
+		Clientset:                 fakekubernetes.NewSimpleClientset(pod),
+	}
+
+	_, err := pf.resolveDestinationPort(context.Background(), "mypod", 0)
+	require.Error(t, err)
+	assert.Equal(t, "pod mypod has no \"prometheus.io/port\" annotation", err.Error())
+}
+
+func TestResolveDestinationPort_NamedTargetPortOnService(t *testing.T) {
+	pod := newPod("mypod", map[string]string{"app": "web"})
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name: "app",
+			Ports: []corev1.ContainerPort{
+				{Name: "http", ContainerPort: 8080},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", TargetPort: intstr.FromString("http")},
+			},
+		},
+	}
+
+	pf := PortForward{
+		resType:             serviceType,
+		resolvedServiceName: "web",
+		Clientset:           fakekubernetes.NewSimpleClientset(pod, svc),
+	}
+
+	port, err := pf.resolveDestinationPort(context.Background(), "mypod", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+}
+
+func TestHandleForwardError_Rediscover(t *testing.T) {
+	calls := 0
+	pf := PortForward{
+		resType: podType,
+		Clientset: fakekubernetes.NewSimpleClientset(
+			newPod("mypod", map[string]string{
+				"name": "flux",
+			})),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"name": "flux",
+			},
+		},
+		Name: "stale-pod",
+		OnError: func(err error) Action {
+			calls++
+			return ActionRediscover
+		},
+	}
+
+	retry := pf.handleForwardError(context.Background(), errors.New("stream closed"))
+	require.True(t, retry)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, StateRetrying, pf.State)
+	assert.Equal(t, "stream closed", pf.LastError.Error())
+
+	// the stale name was forgotten, so the next lookup re-runs the label search.
+	assert.Empty(t, pf.Name)
+	name, err := pf.getResourceName(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "mypod", name)
+}
+
+func TestHandleForwardError_Retry(t *testing.T) {
+	pf := PortForward{
+		Name: "mypod",
+		OnError: func(err error) Action {
+			return ActionRetry
+		},
+	}
+
+	retry := pf.handleForwardError(context.Background(), errors.New("boom"))
+	require.True(t, retry)
+	assert.Equal(t, StateRetrying, pf.State)
+	assert.Equal(t, "mypod", pf.Name)
+}
+
+func TestHandleForwardError_DefaultsToStop(t *testing.T) {
+	pf := PortForward{}
+
+	retry := pf.handleForwardError(context.Background(), errors.New("boom"))
+	require.False(t, retry)
+	assert.Equal(t, StateStopped, pf.State)
+}
+
+func TestHandleForwardError_Stop(t *testing.T) {
+	pf := PortForward{
+		OnError: func(err error) Action {
+			return ActionStop
+		},
+	}
+
+	retry := pf.handleForwardError(context.Background(), errors.New("boom"))
+	require.False(t, retry)
+	assert.Equal(t, StateStopped, pf.State)
+}
+
+func TestStart_ContextAlreadyCanceled(t *testing.T) {
+	pf := PortForward{resType: podType}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := pf.Start(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case <-pf.Done():
+	default:
+		t.Fatal("Done channel was not closed")
+	}
+	assert.ErrorIs(t, pf.Wait(), context.Canceled)
+}
+
+func TestStop_Idempotent(t *testing.T) {
+	pf := PortForward{}
+	pf.mu.Lock()
+	pf.stopRequested = make(chan struct{})
+	pf.mu.Unlock()
+
+	assert.NotPanics(t, func() {
+		pf.Stop()
+		pf.Stop()
+	})
+
+	select {
+	case <-pf.stopRequested:
+	default:
+		t.Fatal("stopRequested was not closed")
+	}
+}
+
+// fakeForwarder stands in for client-go's real *portforward.PortForwarder: once
+// ForwardPorts is called it signals readiness, then blocks until stopChan is
+// closed and returns nil, exactly like the real implementation does whether
+// stopChan closed because of Stop or because ctx was canceled.
+type fakeForwarder struct {
+	stopChan  <-chan struct{}
+	readyChan chan struct{}
+}
+
+func (f *fakeForwarder) ForwardPorts() error {
+	close(f.readyChan)
+	<-f.stopChan
+	return nil
+}
+
+// flakyForwarder signals readiness like the real implementation, then blocks
+// until failOnce is closed, at which point ForwardPorts returns err. It lets a
+// test fail an already-forwarding attempt on demand.
+type flakyForwarder struct {
+	readyChan chan struct{}
+	failOnce  <-chan struct{}
+	err       error
+}
+
+func (f *flakyForwarder) ForwardPorts() error {
+	close(f.readyChan)
+	<-f.failOnce
+	return f.err
+}
+
+// failForwarder fails immediately, without ever becoming ready.
+type failForwarder struct {
+	err error
+}
+
+func (f *failForwarder) ForwardPorts() error {
+	return f.err
+}
+
+// readyThenFailForwarder signals readiness, stays up briefly, then fails,
+// simulating a connection that was sustained for a while before dropping.
+type readyThenFailForwarder struct {
+	readyChan chan struct{}
+	err       error
+}
+
+func (f *readyThenFailForwarder) ForwardPorts() error {
+	close(f.readyChan)
+	time.Sleep(20 * time.Millisecond)
+	return f.err
+}
+
+func TestSuperviseForward_RediscoversAfterFailure(t *testing.T) {
+	origForwarder, origDialer := newPortForwarder, newDialer
+	defer func() { newPortForwarder, newDialer = origForwarder, origDialer }()
+	newDialer = func(p *PortForward, resourceName string) (httpstream.Dialer, error) {
+		return nil, nil
+	}
+
+	var calls int32
+	failOnce := make(chan struct{})
+	newPortForwarder = func(dialer httpstream.Dialer, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut io.Writer) (forwarder, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return &flakyForwarder{readyChan: readyChan, failOnce: failOnce, err: errors.New("stream reset")}, nil
+		}
+		return &fakeForwarder{stopChan: stopChan, readyChan: readyChan}, nil
+	}
+
+	var onErrorCalls int32
+	pf := PortForward{
+		resType: podType,
+		Name:    "stale-pod",
+		Clientset: fakekubernetes.NewSimpleClientset(newPod("mypod", map[string]string{
+			"name": "flux",
+		})),
+		Labels: metav1.LabelSelector{
+			MatchLabels: map[string]string{"name": "flux"},
+		},
+		Mappings: []PortMapping{{Remote: 8080}},
+		OnError: func(err error) Action {
+			atomic.AddInt32(&onErrorCalls, 1)
+			return ActionRediscover
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, pf.Start(ctx))
+
+	// The first attempt was against the stale name; fail it so the supervisor
+	// rediscovers the resource via the label selector for the next attempt.
+	close(failOnce)
+
+	require.Eventually(t, func() bool {
+		pf.mu.Lock()
+		defer pf.mu.Unlock()
+		return pf.Attempts == 2
+	}, 2*time.Second, 10*time.Millisecond, "supervisor never retried after the failure")
+
+	pf.mu.Lock()
+	name, attempts := pf.Name, pf.Attempts
+	pf.mu.Unlock()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&onErrorCalls))
+	assert.Equal(t, "mypod", name)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestSuperviseForward_BackoffResetsAfterSustainedConnection(t *testing.T) {
+	origForwarder, origDialer := newPortForwarder, newDialer
+	defer func() { newPortForwarder, newDialer = origForwarder, origDialer }()
+	newDialer = func(p *PortForward, resourceName string) (httpstream.Dialer, error) {
+		return nil, nil
+	}
+
+	var (
+		mu    sync.Mutex
+		calls int
+		times []time.Time
+	)
+	newPortForwarder = func(dialer httpstream.Dialer, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut io.Writer) (forwarder, error) {
+		mu.Lock()
+		calls++
+		n := calls
+		times = append(times, time.Now())
+		mu.Unlock()
+
+		// The 3rd attempt is the only one that becomes ready, long enough to
+		// demonstrate that only a sustained connection resets the backoff.
+		if n == 3 {
+			return &readyThenFailForwarder{readyChan: readyChan, err: errors.New("dropped")}, nil
+		}
+		return &failForwarder{err: errors.New("refused")}, nil
+	}
+
+	pf := PortForward{
+		resType:  podType,
+		Name:     "mypod",
+		Mappings: []PortMapping{{Remote: 8080}},
+		OnError:  func(err error) Action { return ActionRetry },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first attempt fails before becoming ready, so Start reports that
+	// failure directly; the supervisor keeps retrying in the background
+	// regardless, which is what this test exercises.
+	_ = pf.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls >= 4
+	}, 5*time.Second, 10*time.Millisecond, "supervisor did not retry enough times")
+
+	cancel()
+	<-pf.Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Attempt 3 followed 2 consecutive failures, so it waited out a backoff that
+	// had already doubled once. Attempt 4 followed attempt 3's sustained (if
+	// brief) connection, so it should wait out a freshly reset backoff rather
+	// than one that kept doubling.
+	grown := times[2].Sub(times[1])
+	reset := times[3].Sub(times[2])
+	assert.Less(t, reset, grown)
+}
+
+func TestSuperviseForward_ContextCanceledWhileForwarding(t *testing.T) {
+	origForwarder, origDialer := newPortForwarder, newDialer
+	defer func() { newPortForwarder, newDialer = origForwarder, origDialer }()
+	newDialer = func(p *PortForward, resourceName string) (httpstream.Dialer, error) {
+		return nil, nil
+	}
+	newPortForwarder = func(dialer httpstream.Dialer, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut io.Writer) (forwarder, error) {
+		return &fakeForwarder{stopChan: stopChan, readyChan: readyChan}, nil
+	}
+
+	pf := PortForward{
+		resType:   podType,
+		Name:      "mypod",
+		Clientset: fakekubernetes.NewSimpleClientset(),
+		Mappings:  []PortMapping{{Remote: 8080}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, pf.Start(ctx))
+	assert.Equal(t, StateForwarding, pf.State)
+
+	cancel()
+
+	select {
+	case <-pf.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done was not closed after ctx was canceled")
+	}
+	assert.ErrorIs(t, pf.Wait(), context.Canceled)
+}